@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/lumixraku/custom_routing_in_go/router"
+)
+
+func main() {
+	handler := router.New()
+
+	handler.Get("/hello/{name}", func(resp *router.Response) error {
+		name := router.Vars(resp.Request)["name"]
+		return resp.Text(http.StatusOK, fmt.Sprintf("Hello %s", name))
+	})
+
+	handler.Get("/hello", func(resp *router.Response) error {
+		return resp.Text(http.StatusOK, "Hello world")
+	})
+
+	handler.Get("/users/{id:[0-9]+}", func(resp *router.Response) error {
+		id := router.Vars(resp.Request)["id"]
+		return resp.Text(http.StatusOK, fmt.Sprintf("User %s", id))
+	})
+
+	err := http.ListenAndServe(":9000", handler)
+
+	if err != nil {
+		log.Fatalf("Could not start server: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/lumixraku/custom_routing_in_go/router"
+)
+
+func logging(next router.Handler) router.Handler {
+	return func(resp *router.Response) error {
+		start := time.Now()
+		err := next(resp)
+		log.Printf("%s %s (%s)\n", resp.Request.Method, resp.Request.URL.Path, time.Since(start))
+		return err
+	}
+}
+
+func recovery(next router.Handler) router.Handler {
+	return func(resp *router.Response) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Printf("panic: %v\n", rec)
+				err = resp.Text(http.StatusInternalServerError, "Internal Server Error")
+			}
+		}()
+		return next(resp)
+	}
+}
+
+func main() {
+	handler := router.New()
+	handler.Use(recovery, logging)
+
+	handler.Get("/hello/{name}", func(resp *router.Response) error {
+		name := router.Vars(resp.Request)["name"]
+		return resp.JSON(http.StatusOK, map[string]string{"message": "Hello " + name})
+	})
+
+	err := http.ListenAndServe(":9000", handler)
+
+	if err != nil {
+		log.Fatalf("Could not start server: %s\n", err.Error())
+	}
+}
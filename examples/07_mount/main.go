@@ -0,0 +1,26 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/lumixraku/custom_routing_in_go/router"
+)
+
+func main() {
+	handler := router.New()
+
+	handler.Mount("/static/", http.FileServer(http.Dir("./public")))
+
+	api := handler.Group("/api/v1")
+	api.Get("/users/{id:[0-9]+}", func(resp *router.Response) error {
+		id := router.Vars(resp.Request)["id"]
+		return resp.JSON(http.StatusOK, map[string]string{"id": id})
+	})
+
+	err := http.ListenAndServe(":9000", handler)
+
+	if err != nil {
+		log.Fatalf("Could not start server: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCapturedSlashIsNotTreatedAsSegmentBoundary(t *testing.T) {
+	rt := New()
+	var got string
+	if err := rt.Get("/files/{path}", func(resp *Response) error {
+		got = Vars(resp.Request)["path"]
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/files/a%2Fb", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got != "a/b" {
+		t.Fatalf("captured path = %q, want %q (the encoded slash should stay inside the single parameter)", got, "a/b")
+	}
+}
+
+func TestCapturedValueIsUnescaped(t *testing.T) {
+	rt := New()
+	var got string
+	if err := rt.Get("/search/{term}", func(resp *Response) error {
+		got = Vars(resp.Request)["term"]
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/search/hello%20world", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got != "hello world" {
+		t.Fatalf("captured term = %q, want %q", got, "hello world")
+	}
+}
@@ -0,0 +1,66 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSetNotFoundOverridesDefault(t *testing.T) {
+	rt := New()
+	rt.SetNotFound(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/nowhere", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+}
+
+func TestSetMethodNotAllowedOverridesDefault(t *testing.T) {
+	rt := New()
+	rt.SetMethodNotAllowed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	if err := rt.Get("/hello", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/hello", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTeapot {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTeapot)
+	}
+	if w.Header().Get("Allow") != http.MethodGet {
+		t.Fatalf("Allow header = %q, want %q (ServeHTTP should still set it before calling the custom handler)", w.Header().Get("Allow"), http.MethodGet)
+	}
+}
+
+func TestDefaultNotFoundAndMethodNotAllowed(t *testing.T) {
+	rt := New()
+	if err := rt.Get("/hello", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	notFound := httptest.NewRecorder()
+	rt.ServeHTTP(notFound, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if notFound.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", notFound.Code, http.StatusNotFound)
+	}
+
+	notAllowed := httptest.NewRecorder()
+	rt.ServeHTTP(notAllowed, httptest.NewRequest(http.MethodPost, "/hello", nil))
+	if notAllowed.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", notAllowed.Code, http.StatusMethodNotAllowed)
+	}
+}
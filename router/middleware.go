@@ -0,0 +1,20 @@
+package router
+
+// Middleware wraps a Handler to observe or short-circuit the request
+// before and/or after the next Handler runs.
+type Middleware func(next Handler) Handler
+
+// Use registers middleware to run, in registration order, around every
+// handler on this Router.
+func (rt *Router) Use(mw ...Middleware) {
+	rt.middleware = append(rt.middleware, mw...)
+}
+
+// wrap applies the router's middleware chain around h, with the first
+// middleware registered becoming the outermost layer.
+func (rt *Router) wrap(h Handler) Handler {
+	for i := len(rt.middleware) - 1; i >= 0; i-- {
+		h = rt.middleware[i](h)
+	}
+	return h
+}
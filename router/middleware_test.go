@@ -0,0 +1,89 @@
+package router
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUseRunsMiddlewareOutermostFirst(t *testing.T) {
+	rt := New()
+	var order []string
+	mw := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(resp *Response) error {
+				order = append(order, name+":before")
+				err := next(resp)
+				order = append(order, name+":after")
+				return err
+			}
+		}
+	}
+	rt.Use(mw("outer"), mw("inner"))
+	if err := rt.Get("/hello", func(resp *Response) error {
+		order = append(order, "handler")
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+
+	want := []string{"outer:before", "inner:before", "handler", "inner:after", "outer:after"}
+	if len(order) != len(want) {
+		t.Fatalf("call order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("call order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestMiddlewareObservesHandlerError(t *testing.T) {
+	rt := New()
+	var observed error
+	rt.Use(func(next Handler) Handler {
+		return func(resp *Response) error {
+			err := next(resp)
+			observed = err
+			return err
+		}
+	})
+
+	wantErr := errors.New("boom")
+	if err := rt.Get("/hello", func(resp *Response) error {
+		resp.WriteHeader(http.StatusInternalServerError)
+		return wantErr
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/hello", nil)
+	rt.ServeHTTP(httptest.NewRecorder(), req)
+
+	if observed != wantErr {
+		t.Fatalf("middleware observed error = %v, want %v", observed, wantErr)
+	}
+}
+
+func TestResponseJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	resp := &Response{ResponseWriter: w, Request: httptest.NewRequest(http.MethodGet, "/", nil)}
+
+	if err := resp.JSON(http.StatusCreated, map[string]string{"hello": "world"}); err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want %q", ct, "application/json")
+	}
+	if want := "{\"hello\":\"world\"}\n"; w.Body.String() != want {
+		t.Fatalf("body = %q, want %q", w.Body.String(), want)
+	}
+}
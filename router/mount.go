@@ -0,0 +1,90 @@
+package router
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// mountEntry pairs a prefix with the handler that requests under it are
+// delegated to once the prefix has been stripped.
+type mountEntry struct {
+	prefix  string
+	handler http.Handler
+}
+
+// Mount attaches h to handle every request whose path falls under prefix,
+// stripping prefix from the request path before delegating — analogous to
+// http.StripPrefix(prefix, h). This lets a file server or an entire
+// subrouter be attached under a path without registering every leaf
+// route on the parent Router.
+func (rt *Router) Mount(prefix string, h http.Handler) {
+	rt.mounts = append(rt.mounts, mountEntry{prefix: normalizePrefix(prefix), handler: h})
+}
+
+// Group returns a new Router mounted under prefix. Middleware registered
+// on the returned Router via Use only runs for requests that actually
+// fall under prefix, since the group is only reached once ServeHTTP has
+// already matched the mount.
+func (rt *Router) Group(prefix string) *Router {
+	sub := New()
+	rt.Mount(prefix, sub)
+	return sub
+}
+
+// matchMount finds the first mount whose prefix contains escapedPath,
+// returning its handler and the still-percent-encoded request path with
+// that prefix stripped. escapedPath must be r.URL.EscapedPath(), not
+// r.URL.Path, so a "/" encoded inside the stripped remainder (e.g.
+// "a%2Fb") survives into the delegated request the same way chunk0-3
+// preserves it for direct route matching.
+func (rt *Router) matchMount(escapedPath string) (http.Handler, string, bool) {
+	for _, m := range rt.mounts {
+		root := strings.TrimSuffix(m.prefix, "/")
+		if escapedPath != root && !strings.HasPrefix(escapedPath, m.prefix) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(escapedPath, root)
+		if rest == "" {
+			rest = "/"
+		}
+		return m.handler, rest, true
+	}
+	return nil, "", false
+}
+
+// normalizePrefix ensures a mount prefix has both leading and trailing
+// slashes so prefix matching and stripping behave consistently regardless
+// of how the caller wrote it.
+func normalizePrefix(prefix string) string {
+	if !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix = prefix + "/"
+	}
+	return prefix
+}
+
+// withPath returns a shallow copy of r with its URL path (and any vars
+// already captured by a parent router, carried over via r.Clone's
+// context) replaced by rawRest, a still-percent-encoded path as returned
+// by matchMount. Both Path and RawPath are set from it, the same way
+// net/http populates a request's URL, so a delegated subrouter or
+// http.Handler sees the same EscapedPath()-preserving behavior as the
+// parent router's own route matching.
+func withPath(r *http.Request, rawRest string) *http.Request {
+	r2 := r.Clone(r.Context())
+	u := *r2.URL
+
+	if decoded, err := url.PathUnescape(rawRest); err == nil {
+		u.Path = decoded
+	} else {
+		u.Path = rawRest
+	}
+	u.RawPath = rawRest
+
+	r2.URL = &u
+	return r2
+}
@@ -0,0 +1,65 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMountStripsPrefix(t *testing.T) {
+	rt := New()
+	var gotPath string
+	rt.Mount("/static", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/static/css/site.css", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if gotPath != "/css/site.css" {
+		t.Fatalf("stripped path = %q, want %q", gotPath, "/css/site.css")
+	}
+}
+
+func TestGroupPreservesEscapedSlashThroughMount(t *testing.T) {
+	rt := New()
+	sub := rt.Group("/api")
+	var gotName string
+	if err := sub.Get("/{name}", func(resp *Response) error {
+		gotName = Vars(resp.Request)["name"]
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/a%2Fb", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if gotName != "a/b" {
+		t.Fatalf("captured name = %q, want %q (escaped slash routed through a Mount/Group should survive unescaped, not split the path)", gotName, "a/b")
+	}
+}
+
+func TestMountNotFoundFallsThrough(t *testing.T) {
+	rt := New()
+	rt.Mount("/static", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/unmounted", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
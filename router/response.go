@@ -0,0 +1,73 @@
+package router
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// Response wraps the http.ResponseWriter and *http.Request for a single
+// request, giving handlers a single typed value to read from and write
+// through instead of the repeated "resp := Response{w}" boilerplate.
+type Response struct {
+	http.ResponseWriter
+	Request *http.Request
+}
+
+// responsePool lets ServeHTTP reuse a *Response across requests instead
+// of allocating one per request; it's reset and returned to the pool
+// once the handler chain for a request has finished.
+var responsePool = sync.Pool{
+	New: func() interface{} { return new(Response) },
+}
+
+// Handler is the signature router handlers are registered with. Returning
+// an error lets middleware (e.g. a recovery or logging layer) observe and
+// react to failures uniformly instead of each handler writing its own
+// error response.
+type Handler func(*Response) error
+
+// JSON writes v as a JSON-encoded body with the given status code.
+func (resp *Response) JSON(code int, v interface{}) error {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(code)
+	return json.NewEncoder(resp).Encode(v)
+}
+
+// XML writes v as an XML-encoded body with the given status code.
+func (resp *Response) XML(code int, v interface{}) error {
+	resp.Header().Set("Content-Type", "application/xml")
+	resp.WriteHeader(code)
+	return xml.NewEncoder(resp).Encode(v)
+}
+
+// HTML writes body as an HTML response with the given status code.
+func (resp *Response) HTML(code int, body string) error {
+	resp.Header().Set("Content-Type", "text/html")
+	resp.WriteHeader(code)
+	_, err := io.WriteString(resp, body)
+	return err
+}
+
+// Text writes body as a plain text response with the given status code.
+func (resp *Response) Text(code int, body string) error {
+	resp.Header().Set("Content-Type", "text/plain")
+	resp.WriteHeader(code)
+	_, err := io.WriteString(resp, fmt.Sprintf("%s\n", body))
+	return err
+}
+
+// Redirect replies with a redirect to url using the given status code.
+func (resp *Response) Redirect(code int, url string) error {
+	http.Redirect(resp, resp.Request, url, code)
+	return nil
+}
+
+// NoContent writes only the given status code and no body.
+func (resp *Response) NoContent(code int) error {
+	resp.WriteHeader(code)
+	return nil
+}
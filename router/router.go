@@ -0,0 +1,263 @@
+// Package router implements a small pattern-based HTTP router supporting
+// named path parameters (e.g. "/hello/{name}") and regex-constrained
+// parameters (e.g. "/users/{id:[0-9]+}"), in place of manual
+// strings.Replace/strings.HasPrefix parsing against http.ServeMux.
+//
+// Registered patterns are compiled into a radix tree (see tree.go) keyed
+// by path segment, so matching a request costs O(path length) rather than
+// a linear scan of every registered route.
+package router
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// segmentKind classifies a single "/"-delimited piece of a route pattern.
+type segmentKind int
+
+const (
+	segmentLiteral segmentKind = iota
+	segmentParam
+	segmentRegex
+	segmentCatchAll
+)
+
+// segment is the compiled form of one path piece, ready to be inserted
+// into the router's radix tree.
+type segment struct {
+	kind    segmentKind
+	literal string
+	name    string
+	pattern string
+	regex   *regexp.Regexp
+}
+
+// Router is an HTTP request multiplexer that matches registered path
+// patterns against the request path and dispatches to the handler
+// registered for the request method.
+type Router struct {
+	tree             *node
+	middleware       []Middleware
+	mounts           []mountEntry
+	notFound         http.Handler
+	methodNotAllowed http.Handler
+}
+
+// New returns an empty Router ready to have routes registered on it.
+func New() *Router {
+	return &Router{
+		tree:             newNode(),
+		notFound:         http.HandlerFunc(defaultNotFound),
+		methodNotAllowed: http.HandlerFunc(defaultMethodNotAllowed),
+	}
+}
+
+// SetNotFound replaces the handler invoked when no registered route
+// matches the request path. The default responds with a plain text
+// "Not found" and a 404 status.
+func (rt *Router) SetNotFound(h http.Handler) {
+	rt.notFound = h
+}
+
+// SetMethodNotAllowed replaces the handler invoked when a route matches
+// the request path but not its method. The default responds with a
+// plain text 405 status; either way ServeHTTP sets the Allow header to
+// the methods registered for the matched path before calling it.
+func (rt *Router) SetMethodNotAllowed(h http.Handler) {
+	rt.methodNotAllowed = h
+}
+
+func defaultNotFound(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusNotFound)
+	io.WriteString(w, "Not found\n")
+}
+
+func defaultMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusMethodNotAllowed)
+	io.WriteString(w, "Method not allowed\n")
+}
+
+// Handle registers h to be called for requests matching method and
+// pattern, inserting it into the routing tree. It returns an error if
+// pattern conflicts with an already-registered pattern (e.g.
+// "/users/{id}" vs "/users/{name}") instead of failing at request time.
+func (rt *Router) Handle(method, pattern string, h Handler) error {
+	return rt.tree.insert(pattern, compilePattern(pattern), method, h)
+}
+
+// Get registers h for GET requests matching pattern.
+func (rt *Router) Get(pattern string, h Handler) error {
+	return rt.Handle(http.MethodGet, pattern, h)
+}
+
+// Post registers h for POST requests matching pattern.
+func (rt *Router) Post(pattern string, h Handler) error {
+	return rt.Handle(http.MethodPost, pattern, h)
+}
+
+// Put registers h for PUT requests matching pattern.
+func (rt *Router) Put(pattern string, h Handler) error {
+	return rt.Handle(http.MethodPut, pattern, h)
+}
+
+// Delete registers h for DELETE requests matching pattern.
+func (rt *Router) Delete(pattern string, h Handler) error {
+	return rt.Handle(http.MethodDelete, pattern, h)
+}
+
+// Patch registers h for PATCH requests matching pattern.
+func (rt *Router) Patch(pattern string, h Handler) error {
+	return rt.Handle(http.MethodPatch, pattern, h)
+}
+
+// ServeHTTP implements http.Handler. It walks the radix tree looking for
+// a segment match; if the path matches but not the method, it responds
+// via methodNotAllowed with an Allow header. If no route or mount
+// matches the path at all, it responds via notFound.
+//
+// The per-request segment slice, captured-param map and Response are all
+// drawn from pools, so a matched request allocates only what actually
+// escapes the request's lifetime: the params map when the route captured
+// something, and whatever the matched Handler itself allocates.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	segsPtr := segBufPool.Get().(*[]string)
+	// Match against the raw, still-percent-encoded path so a "/" or other
+	// reserved character encoded inside a single parameter value (e.g.
+	// "a%2Fb") isn't mistaken for a segment boundary. r.URL.Path has
+	// already been decoded by net/http and would collapse that distinction.
+	segs := splitPathInto(r.URL.EscapedPath(), (*segsPtr)[:0])
+	*segsPtr = segs
+
+	buf := paramBufPool.Get().(*paramBuf)
+	buf.reset()
+
+	// pathMatched records the last node search found whose full path
+	// matched but whose method didn't, so a sibling route that matches
+	// both path and method (e.g. a param route behind a higher-priority
+	// static one) is still tried before giving up and reporting 405.
+	var pathMatched *node
+	match := rt.tree.search(segs, 0, r.Method, buf, &pathMatched)
+
+	if match != nil {
+		if buf.n > 0 {
+			r = r.WithContext(context.WithValue(r.Context(), varsKey{}, buf.toMap()))
+		}
+		paramBufPool.Put(buf)
+		segBufPool.Put(segsPtr)
+
+		resp := responsePool.Get().(*Response)
+		resp.ResponseWriter = w
+		resp.Request = r
+		_ = rt.wrap(match.handlers[r.Method])(resp)
+		resp.ResponseWriter = nil
+		resp.Request = nil
+		responsePool.Put(resp)
+		return
+	}
+	paramBufPool.Put(buf)
+	segBufPool.Put(segsPtr)
+
+	if pathMatched != nil {
+		w.Header().Set("Allow", strings.Join(allowedMethods(pathMatched.handlers), ", "))
+		rt.methodNotAllowed.ServeHTTP(w, r)
+		return
+	}
+
+	if h, rest, ok := rt.matchMount(r.URL.EscapedPath()); ok {
+		h.ServeHTTP(w, withPath(r, rest))
+		return
+	}
+
+	rt.notFound.ServeHTTP(w, r)
+}
+
+func allowedMethods(handlers map[string]Handler) []string {
+	methods := make([]string, 0, len(handlers))
+	for m := range handlers {
+		methods = append(methods, m)
+	}
+	return methods
+}
+
+// splitPath splits a URL path into its non-empty "/"-delimited segments.
+// It's only used at registration time (compiling a pattern); matching a
+// request uses the pooled splitPathInto instead so routing an incoming
+// request doesn't allocate a fresh slice every time.
+func splitPath(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// maxPooledSegments is how many path segments segBufPool's buffers hold
+// inline; deeper paths still work, just by growing (and allocating) the
+// slice for that one request.
+const maxPooledSegments = 16
+
+var segBufPool = sync.Pool{
+	New: func() interface{} {
+		s := make([]string, 0, maxPooledSegments)
+		return &s
+	},
+}
+
+// splitPathInto splits p the same way splitPath does, appending into buf
+// (expected to be a pooled, zero-length slice) instead of allocating a
+// new one.
+func splitPathInto(p string, buf []string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return buf
+	}
+	for {
+		i := strings.IndexByte(p, '/')
+		if i < 0 {
+			return append(buf, p)
+		}
+		buf = append(buf, p[:i])
+		p = p[i+1:]
+	}
+}
+
+// compilePattern splits and compiles a route pattern such as
+// "/users/{id:[0-9]+}" into a segment per path piece.
+func compilePattern(pattern string) []segment {
+	parts := splitPath(pattern)
+	segs := make([]segment, len(parts))
+	for i, part := range parts {
+		segs[i] = compileSegment(part)
+	}
+	return segs
+}
+
+func compileSegment(part string) segment {
+	if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+		return segment{kind: segmentLiteral, literal: part}
+	}
+
+	inner := part[1 : len(part)-1]
+	if strings.HasSuffix(inner, "...") {
+		return segment{kind: segmentCatchAll, name: strings.TrimSuffix(inner, "...")}
+	}
+
+	name, pattern, hasPattern := strings.Cut(inner, ":")
+	if !hasPattern {
+		return segment{kind: segmentParam, name: name}
+	}
+
+	return segment{
+		kind:    segmentRegex,
+		name:    name,
+		pattern: pattern,
+		regex:   regexp.MustCompile("^" + pattern + "$"),
+	}
+}
@@ -0,0 +1,109 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// benchResources is a realistic 100-route-ish REST table: a handful of
+// resources, each with the usual collection/item/nested-collection
+// routes, used to compare the tree-based Router against http.ServeMux.
+//
+// Expect BenchmarkRouter_StaticRoute to match ServeMux at 0 allocs/op —
+// the segment buffer, paramBuf and Response are all pool-backed, so a
+// route with no captures costs nothing beyond the match itself.
+// BenchmarkRouter_ParamRoute will still show a handful of allocs/op:
+// Vars returns a plain map[string]string, and populating it plus
+// r.WithContext (which net/http documents as always returning a new
+// *http.Request) both allocate by design. Pooling parameters further
+// would mean giving up the map-based Vars API, which isn't worth it for
+// the constant-factor win on routes that capture parameters.
+var benchResources = []string{
+	"accounts", "users", "orders", "invoices", "products", "carts",
+	"sessions", "payments", "shipments", "reviews", "coupons", "addresses",
+	"notifications", "subscriptions", "tickets", "teams", "projects",
+	"tasks", "comments", "tags",
+}
+
+func buildBenchRouter() *Router {
+	rt := New()
+	for _, res := range benchResources {
+		rt.Get("/"+res, noopHandler)
+		rt.Post("/"+res, noopHandler)
+		rt.Get("/"+res+"/{id:[0-9]+}", noopHandler)
+		rt.Put("/"+res+"/{id:[0-9]+}", noopHandler)
+		rt.Delete("/"+res+"/{id:[0-9]+}", noopHandler)
+	}
+	return rt
+}
+
+func buildBenchServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, res := range benchResources {
+		mux.HandleFunc("/"+res, noopHandlerFunc)
+		mux.HandleFunc("/"+res+"/", noopHandlerFunc)
+	}
+	return mux
+}
+
+func noopHandler(resp *Response) error {
+	return resp.NoContent(http.StatusOK)
+}
+
+func noopHandlerFunc(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func BenchmarkRouter_StaticRoute(b *testing.B) {
+	rt := buildBenchRouter()
+	req := httptest.NewRequest(http.MethodGet, "/"+benchResources[len(benchResources)-1], nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rt.ServeHTTP(w, req)
+	}
+}
+
+func BenchmarkServeMux_StaticRoute(b *testing.B) {
+	mux := buildBenchServeMux()
+	req := httptest.NewRequest(http.MethodGet, "/"+benchResources[len(benchResources)-1], nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mux.ServeHTTP(w, req)
+	}
+}
+
+func BenchmarkRouter_ParamRoute(b *testing.B) {
+	rt := buildBenchRouter()
+	path := fmt.Sprintf("/%s/42", benchResources[len(benchResources)-1])
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rt.ServeHTTP(w, req)
+	}
+}
+
+func BenchmarkServeMux_ParamRoute(b *testing.B) {
+	// http.ServeMux has no path parameters; the closest equivalent is a
+	// prefix subtree handler that parses the id itself.
+	mux := buildBenchServeMux()
+	path := fmt.Sprintf("/%s/42", benchResources[len(benchResources)-1])
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mux.ServeHTTP(w, req)
+	}
+}
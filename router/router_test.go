@@ -0,0 +1,117 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetNamedParam(t *testing.T) {
+	rt := New()
+	var got string
+	if err := rt.Get("/hello/{name}", func(resp *Response) error {
+		got = Vars(resp.Request)["name"]
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/hello/world", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got != "world" {
+		t.Fatalf("captured name = %q, want %q", got, "world")
+	}
+}
+
+func TestRegexParamRejectsNonMatchingSegment(t *testing.T) {
+	rt := New()
+	if err := rt.Get("/users/{id:[0-9]+}", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/users/abc", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d (\"abc\" should not satisfy {id:[0-9]+})", w.Code, http.StatusNotFound)
+	}
+}
+
+func TestMethodNotAllowedSetsAllowHeader(t *testing.T) {
+	rt := New()
+	if err := rt.Get("/users/{id:[0-9]+}", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := rt.Delete("/users/{id:[0-9]+}", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users/42", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusMethodNotAllowed)
+	}
+	allow := w.Header().Get("Allow")
+	if !strings.Contains(allow, http.MethodGet) || !strings.Contains(allow, http.MethodDelete) {
+		t.Fatalf("Allow header = %q, want it to list GET and DELETE", allow)
+	}
+}
+
+func TestMethodSearchTriesLowerPrioritySiblings(t *testing.T) {
+	rt := New()
+	if err := rt.Get("/users/list", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	var gotID string
+	if err := rt.Post("/users/{id}", func(resp *Response) error {
+		gotID = Vars(resp.Request)["id"]
+		return resp.NoContent(http.StatusCreated)
+	}); err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/users/list", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d (POST should fall through the path-only GET match to the param route)", w.Code, http.StatusCreated)
+	}
+	if gotID != "list" {
+		t.Fatalf("captured id = %q, want %q", gotID, "list")
+	}
+}
+
+func TestNoRouteMatchesNotFound(t *testing.T) {
+	rt := New()
+	if err := rt.Get("/hello", func(resp *Response) error {
+		return resp.NoContent(http.StatusOK)
+	}); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/goodbye", nil)
+	w := httptest.NewRecorder()
+	rt.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,243 @@
+package router
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// regexEdge is a regex-constrained child of a node, e.g. the
+// "{id:[0-9]+}" edge out of the "/users" node.
+type regexEdge struct {
+	name    string
+	pattern string
+	regex   *regexp.Regexp
+	node    *node
+}
+
+// node is one segment of the routing tree. Children are tried in
+// priority order at request time: static (exact map lookup) first, then
+// regex-constrained edges in registration order, then a single named
+// param edge, then a single catch-all edge.
+type node struct {
+	static       map[string]*node
+	regexes      []*regexEdge
+	param        *node
+	paramName    string
+	catchAll     *node
+	catchAllName string
+	handlers     map[string]Handler
+	pattern      string
+}
+
+func newNode() *node {
+	return &node{static: make(map[string]*node)}
+}
+
+// insert walks/creates the path described by segs, attaching h for
+// method at the leaf. It reports an error instead of silently
+// overwriting when pattern conflicts with a different, already
+// registered pattern occupying the same position in the tree.
+func (n *node) insert(pattern string, segs []segment, method string, h Handler) error {
+	cur := n
+	for i, s := range segs {
+		switch s.kind {
+		case segmentLiteral:
+			next, ok := cur.static[s.literal]
+			if !ok {
+				next = newNode()
+				cur.static[s.literal] = next
+			}
+			cur = next
+
+		case segmentRegex:
+			edge := cur.findRegex(s.pattern)
+			if edge == nil {
+				edge = &regexEdge{name: s.name, pattern: s.pattern, regex: s.regex, node: newNode()}
+				cur.regexes = append(cur.regexes, edge)
+			} else if edge.name != s.name {
+				return fmt.Errorf("router: pattern %q conflicts with an existing route: parameter name %q does not match %q at the same position", pattern, s.name, edge.name)
+			}
+			cur = edge.node
+
+		case segmentParam:
+			if cur.param == nil {
+				cur.param = newNode()
+				cur.paramName = s.name
+			} else if cur.paramName != s.name {
+				return fmt.Errorf("router: pattern %q conflicts with an existing route: parameter name %q does not match %q at the same position", pattern, s.name, cur.paramName)
+			}
+			cur = cur.param
+
+		case segmentCatchAll:
+			if i != len(segs)-1 {
+				return fmt.Errorf("router: pattern %q is invalid: a catch-all segment must be the last segment", pattern)
+			}
+			if cur.catchAll == nil {
+				cur.catchAll = newNode()
+				cur.catchAllName = s.name
+			} else if cur.catchAllName != s.name {
+				return fmt.Errorf("router: pattern %q conflicts with an existing route: catch-all name %q does not match %q", pattern, s.name, cur.catchAllName)
+			}
+			cur = cur.catchAll
+		}
+	}
+
+	if cur.handlers == nil {
+		cur.handlers = make(map[string]Handler)
+		cur.pattern = pattern
+	} else if cur.pattern != pattern {
+		return fmt.Errorf("router: pattern %q conflicts with already-registered pattern %q", pattern, cur.pattern)
+	}
+	if _, exists := cur.handlers[method]; exists {
+		return fmt.Errorf("router: method %s is already registered for pattern %q", method, pattern)
+	}
+	cur.handlers[method] = h
+	return nil
+}
+
+func (n *node) findRegex(pattern string) *regexEdge {
+	for _, e := range n.regexes {
+		if e.pattern == pattern {
+			return e
+		}
+	}
+	return nil
+}
+
+// search walks segs from index i, trying static, regex, param and
+// catch-all children in that priority order and backtracking on
+// failure, returning the leaf node holding a handler for method on a
+// full match. Captured parameter values are appended to buf as the
+// match proceeds and rolled back on backtrack.
+//
+// A node whose full path matches but which has no handler for method is
+// not returned — search keeps backtracking through lower-priority
+// siblings (and, via the caller's recursion, ancestors' remaining
+// children) looking for one that does. Whenever such a path-only match
+// is seen, it's recorded through pathMatched so the caller can still
+// report 405 with the right Allow header if no sibling ever satisfies
+// method.
+func (n *node) search(segs []string, i int, method string, buf *paramBuf, pathMatched **node) *node {
+	if i == len(segs) {
+		if n.handlers == nil {
+			return nil
+		}
+		*pathMatched = n
+		if _, ok := n.handlers[method]; ok {
+			return n
+		}
+		return nil
+	}
+
+	seg := segs[i]
+
+	if child, ok := n.static[seg]; ok {
+		if m := child.search(segs, i+1, method, buf, pathMatched); m != nil {
+			return m
+		}
+	}
+
+	for _, e := range n.regexes {
+		if !e.regex.MatchString(seg) {
+			continue
+		}
+		if m := n.matchChild(e.node, e.name, seg, segs, i, method, buf, pathMatched); m != nil {
+			return m
+		}
+	}
+
+	if n.param != nil {
+		if m := n.matchChild(n.param, n.paramName, seg, segs, i, method, buf, pathMatched); m != nil {
+			return m
+		}
+	}
+
+	if n.catchAll != nil {
+		mark := buf.n
+		value, err := url.PathUnescape(strings.Join(segs[i:], "/"))
+		if err == nil {
+			buf.add(n.catchAllName, value)
+			if n.catchAll.handlers != nil {
+				*pathMatched = n.catchAll
+				if _, ok := n.catchAll.handlers[method]; ok {
+					return n.catchAll
+				}
+			}
+		}
+		buf.n = mark
+	}
+
+	return nil
+}
+
+func (n *node) matchChild(child *node, name, seg string, segs []string, i int, method string, buf *paramBuf, pathMatched **node) *node {
+	mark := buf.n
+	value, err := url.PathUnescape(seg)
+	if err != nil {
+		return nil
+	}
+	buf.add(name, value)
+	if m := child.search(segs, i+1, method, buf, pathMatched); m != nil {
+		return m
+	}
+	buf.n = mark
+	return nil
+}
+
+// maxPooledParams is how many captured parameters paramBuf stores
+// inline before the pool stops helping; routes with more than this many
+// parameters still work, just via an allocation per request.
+const maxPooledParams = 8
+
+// paramBuf accumulates captured parameter name/value pairs during a
+// single tree search using fixed-size arrays pulled from paramBufPool,
+// so a request with no or few captures costs zero heap allocations
+// instead of allocating a fresh map per request.
+type paramBuf struct {
+	names  [maxPooledParams]string
+	values [maxPooledParams]string
+	extra  map[string]string // overflow past maxPooledParams, rare
+	n      int
+}
+
+var paramBufPool = sync.Pool{
+	New: func() interface{} { return new(paramBuf) },
+}
+
+func (p *paramBuf) add(name, value string) {
+	if p.n < maxPooledParams {
+		p.names[p.n] = name
+		p.values[p.n] = value
+		p.n++
+		return
+	}
+	if p.extra == nil {
+		p.extra = make(map[string]string)
+	}
+	p.extra[name] = value
+}
+
+func (p *paramBuf) reset() {
+	p.n = 0
+	p.extra = nil
+}
+
+// toMap copies the buffered captures into a fresh map for the request
+// context. Routes with no parameters never reach this, so the common
+// case of a static or single-handler route pays no allocation here.
+func (p *paramBuf) toMap() map[string]string {
+	if p.n == 0 && len(p.extra) == 0 {
+		return nil
+	}
+	m := make(map[string]string, p.n+len(p.extra))
+	for i := 0; i < p.n; i++ {
+		m[p.names[i]] = p.values[i]
+	}
+	for k, v := range p.extra {
+		m[k] = v
+	}
+	return m
+}
@@ -0,0 +1,17 @@
+package router
+
+import "net/http"
+
+// varsKey is the context key under which the matched route's captured
+// path parameters are stored.
+type varsKey struct{}
+
+// Vars returns the named path parameters captured for the route that
+// matched r, e.g. {"id": "42"} for a pattern of "/users/{id}". It
+// returns an empty, non-nil map if the route had no parameters.
+func Vars(r *http.Request) map[string]string {
+	if v, ok := r.Context().Value(varsKey{}).(map[string]string); ok {
+		return v
+	}
+	return map[string]string{}
+}